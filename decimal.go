@@ -0,0 +1,123 @@
+package hitbtc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a fixed-point number backed directly by HitBTC's
+// string-encoded amounts. Round-tripping an amount like 0.00000001 BTC
+// through float64 loses precision and can produce values that violate a
+// symbol's tick size; Decimal instead keeps the API's original string
+// representation end to end.
+type Decimal struct {
+	raw string
+}
+
+// NewDecimal parses s, as returned by the HitBTC API, into a Decimal.
+func NewDecimal(s string) (Decimal, error) {
+	if s == "" {
+		return Decimal{}, nil
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return Decimal{}, fmt.Errorf("hitbtc: invalid decimal %q: %w", s, err)
+	}
+	return Decimal{raw: s}, nil
+}
+
+// DecimalFromFloat converts f to a Decimal, formatted without scientific
+// notation or trailing zeros. Prefer NewDecimal when the value came from
+// the API as a string; this constructor is for caller-supplied amounts.
+func DecimalFromFloat(f float64) Decimal {
+	return Decimal{raw: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+// String returns the decimal's canonical string representation, suitable
+// for sending back to the API.
+func (d Decimal) String() string {
+	if d.raw == "" {
+		return "0"
+	}
+	return d.raw
+}
+
+// Float64 returns the decimal as a float64, for display or arithmetic that
+// doesn't need exchange-grade precision.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.raw, 64)
+	return f
+}
+
+// IsZero reports whether d is the zero value or parses to zero.
+func (d Decimal) IsZero() bool {
+	return d.raw == "" || d.Float64() == 0
+}
+
+// MarshalJSON encodes d the way the HitBTC API expects amounts: as a JSON
+// string, not a number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON reads a HitBTC string-encoded amount directly, without
+// going through float64.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		d.raw = ""
+		return nil
+	}
+	d.raw = string(data)
+	return nil
+}
+
+// RoundToTick rounds price down to the nearest multiple of symbol's tick
+// size, as HitBTC requires before a limit order will be accepted.
+func (b *HitBtc) RoundToTick(symbol string, price Decimal) (Decimal, error) {
+	s, err := b.GetSymbol(symbol)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return roundToStep(price, s.TickSize)
+}
+
+// RoundToQuantityIncrement rounds quantity down to the nearest multiple of
+// symbol's quantity increment, as HitBTC requires before an order will be
+// accepted.
+func (b *HitBtc) RoundToQuantityIncrement(symbol string, quantity Decimal) (Decimal, error) {
+	s, err := b.GetSymbol(symbol)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return roundToStep(quantity, s.QuantityIncrement)
+}
+
+// roundToStep rounds value down to the nearest multiple of step, keeping
+// step's number of decimal places.
+func roundToStep(value, step Decimal) (Decimal, error) {
+	v, ok := new(big.Rat).SetString(value.String())
+	if !ok {
+		return Decimal{}, fmt.Errorf("hitbtc: invalid decimal %q", value.String())
+	}
+	st, ok := new(big.Rat).SetString(step.String())
+	if !ok || st.Sign() == 0 {
+		return value, nil
+	}
+
+	steps := new(big.Rat).Quo(v, st)
+	flooredSteps := new(big.Int).Div(steps.Num(), steps.Denom())
+	rounded := new(big.Rat).Mul(new(big.Rat).SetInt(flooredSteps), st)
+
+	return Decimal{raw: rounded.FloatString(decimalPlaces(step))}, nil
+}
+
+func decimalPlaces(d Decimal) int {
+	s := d.String()
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
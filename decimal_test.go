@@ -0,0 +1,64 @@
+package hitbtc
+
+import "testing"
+
+func TestDecimalUnmarshalJSON(t *testing.T) {
+	var d Decimal
+	if err := d.UnmarshalJSON([]byte(`"0.00000001"`)); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := d.String(); got != "0.00000001" {
+		t.Fatalf("got %q, want 0.00000001", got)
+	}
+}
+
+func TestDecimalUnmarshalJSONNull(t *testing.T) {
+	var d Decimal
+	if err := d.UnmarshalJSON([]byte(`null`)); err != nil {
+		t.Fatalf("unmarshal null: %v", err)
+	}
+	if !d.IsZero() {
+		t.Fatalf("expected null to unmarshal to the zero value, got %q", d.String())
+	}
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	d := DecimalFromFloat(0.00000001)
+	b, err := d.MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(b) != `"0.00000001"` {
+		t.Fatalf("got %s, want \"0.00000001\"", b)
+	}
+}
+
+func TestRoundToStep(t *testing.T) {
+	cases := []struct {
+		value, step, want string
+	}{
+		{"1.2345", "0.01", "1.23"},
+		{"1.29999", "0.01", "1.29"},
+		{"100", "1", "100"},
+		{"0.123456789", "0.00000001", "0.12345678"},
+	}
+
+	for _, c := range cases {
+		value, err := NewDecimal(c.value)
+		if err != nil {
+			t.Fatalf("NewDecimal(%q): %v", c.value, err)
+		}
+		step, err := NewDecimal(c.step)
+		if err != nil {
+			t.Fatalf("NewDecimal(%q): %v", c.step, err)
+		}
+
+		got, err := roundToStep(value, step)
+		if err != nil {
+			t.Fatalf("roundToStep(%q, %q): %v", c.value, c.step, err)
+		}
+		if got.String() != c.want {
+			t.Errorf("roundToStep(%q, %q) = %q, want %q", c.value, c.step, got.String(), c.want)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -18,19 +17,19 @@ const (
 // New returns an instantiated HitBTC struct
 func New(apiKey, apiSecret string) *HitBtc {
 	client := NewClient(apiKey, apiSecret)
-	return &HitBtc{client}
+	return &HitBtc{client: client}
 }
 
 // NewWithCustomHttpClient returns an instantiated HitBTC struct with custom http client
 func NewWithCustomHttpClient(apiKey, apiSecret string, httpClient *http.Client) *HitBtc {
 	client := NewClientWithCustomHttpConfig(apiKey, apiSecret, httpClient)
-	return &HitBtc{client}
+	return &HitBtc{client: client}
 }
 
 // NewWithCustomTimeout returns an instantiated HitBTC struct with custom timeout
 func NewWithCustomTimeout(apiKey, apiSecret string, timeout time.Duration) *HitBtc {
 	client := NewClientWithCustomTimeout(apiKey, apiSecret, timeout)
-	return &HitBtc{client}
+	return &HitBtc{client: client}
 }
 
 // handleErr gets JSON response from livecoin API en deal with error
@@ -59,6 +58,12 @@ func handleErr(r interface{}) error {
 // HitBtc represent a HitBTC client
 type HitBtc struct {
 	client *client
+	RateLimits
+
+	// DataProvider, if set, routes GetTicker and GetCandles through it
+	// instead of hitting HitBTC directly — typically a
+	// PrimaryWithFallback so callers survive a HitBTC outage.
+	DataProvider MarketDataProvider
 }
 
 // set enable/disable http request/response dump
@@ -68,7 +73,7 @@ func (c *HitBtc) SetDebug(enable bool) {
 
 // GetCurrencies is used to get all supported currencies at HitBtc along with other meta data.
 func (b *HitBtc) GetCurrencies() (currencies []Currency, err error) {
-	r, err := b.client.do("GET", "public/currency", nil, false)
+	r, err := b.do(CategoryPublic, "GET", "public/currency", nil, false)
 	if err != nil {
 		return
 	}
@@ -85,7 +90,7 @@ func (b *HitBtc) GetCurrencies() (currencies []Currency, err error) {
 
 // GetSymbols is used to get the open and available trading markets at HitBtc along with other meta data.
 func (b *HitBtc) GetSymbols() (symbols []Symbol, err error) {
-	r, err := b.client.do("GET", "public/symbol", nil, false)
+	r, err := b.do(CategoryPublic, "GET", "public/symbol", nil, false)
 	if err != nil {
 		return
 	}
@@ -101,7 +106,7 @@ func (b *HitBtc) GetSymbols() (symbols []Symbol, err error) {
 }
 
 func (b *HitBtc) GetSymbol(symbol string) (s Symbol, err error) {
-	r, err := b.client.do("GET", "public/symbol/"+strings.ToUpper(symbol), nil, false)
+	r, err := b.do(CategoryPublic, "GET", "public/symbol/"+strings.ToUpper(symbol), nil, false)
 	if err != nil {
 		return
 	}
@@ -116,9 +121,20 @@ func (b *HitBtc) GetSymbol(symbol string) (s Symbol, err error) {
 	return
 }
 
-// GetTicker is used to get the current ticker values for a market.
+// GetTicker is used to get the current ticker values for a market. If
+// DataProvider is set, the request is routed through it instead.
 func (b *HitBtc) GetTicker(market string) (ticker Ticker, err error) {
-	r, err := b.client.do("GET", "public/ticker/"+strings.ToUpper(market), nil, false)
+	if b.DataProvider != nil {
+		return b.DataProvider.GetTicker(market)
+	}
+	return b.getTickerREST(market)
+}
+
+// getTickerREST always hits HitBTC directly. NewHitBtcProvider wraps this
+// instead of GetTicker so that using it as PrimaryWithFallback's primary
+// doesn't recurse back through DataProvider.
+func (b *HitBtc) getTickerREST(market string) (ticker Ticker, err error) {
+	r, err := b.do(CategoryMarketData, "GET", "public/ticker/"+strings.ToUpper(market), nil, false)
 	if err != nil {
 		return
 	}
@@ -134,7 +150,7 @@ func (b *HitBtc) GetTicker(market string) (ticker Ticker, err error) {
 }
 
 func (b *HitBtc) GetTickers() (ticker []Ticker, err error) {
-	r, err := b.client.do("GET", "public/ticker", nil, false)
+	r, err := b.do(CategoryMarketData, "GET", "public/ticker", nil, false)
 	if err != nil {
 		return
 	}
@@ -150,25 +166,37 @@ func (b *HitBtc) GetTickers() (ticker []Ticker, err error) {
 }
 
 type Candle struct {
-	Timestamp   string `json:"timestamp"`
-	Open        string `json:"open"`
-	Close       string `json:"close"`
-	Low         string `json:"min"`
-	High        string `json:"max"`
-	Volume      string `json:"volume"`
-	VolumeQuote string `json:"volumeQuote"`
+	Timestamp   string  `json:"timestamp"`
+	Open        Decimal `json:"open"`
+	Close       Decimal `json:"close"`
+	Low         Decimal `json:"min"`
+	High        Decimal `json:"max"`
+	Volume      Decimal `json:"volume"`
+	VolumeQuote Decimal `json:"volumeQuote"`
 }
 
-func (b *HitBtc) GetCandles(symbol string, period string, limit int) (candles []Candle, err error) {
-	payload := make(map[string]string)
-	if limit > 0 {
-		payload["limit"] = strconv.Itoa(limit)
+// GetCandles is used to get OHLC candles for symbol. If DataProvider is
+// set and no options are given, the request is routed through it instead.
+func (b *HitBtc) GetCandles(symbol string, period string, opts ...Option) (candles []Candle, err error) {
+	if b.DataProvider != nil && len(opts) == 0 {
+		return b.DataProvider.GetCandles(symbol, period)
 	}
+	return b.getCandlesREST(symbol, period, opts...)
+}
+
+// getCandlesREST always hits HitBTC directly. NewHitBtcProvider wraps
+// this instead of GetCandles so that using it as PrimaryWithFallback's
+// primary doesn't recurse back through DataProvider.
+func (b *HitBtc) getCandlesREST(symbol string, period string, opts ...Option) (candles []Candle, err error) {
+	payload := make(map[string]string)
 	if period != "" {
 		payload["period"] = strings.ToUpper(period)
 	}
+	for _, opt := range opts {
+		opt(payload)
+	}
 
-	r, err := b.client.do("GET", fmt.Sprintf("public/candles/%s", strings.ToUpper(symbol)), payload, false)
+	r, err := b.do(CategoryMarketData, "GET", fmt.Sprintf("public/candles/%s", strings.ToUpper(symbol)), payload, false)
 	if err != nil {
 		return
 	}
@@ -189,7 +217,7 @@ func (b *HitBtc) GetCandles(symbol string, period string, limit int) (candles []
 
 // GetBalances is used to retrieve all balances from your account
 func (b *HitBtc) GetBalances() (balances []Balance, err error) {
-	r, err := b.client.do("GET", "trading/balance", nil, true)
+	r, err := b.do(CategoryTrading, "GET", "trading/balance", nil, true)
 	if err != nil {
 		return
 	}
@@ -207,7 +235,7 @@ func (b *HitBtc) GetBalances() (balances []Balance, err error) {
 // Getbalance is used to retrieve the balance from your account for a specific currency.
 // currency: a string literal for the currency (ex: LTC)
 func (b *HitBtc) GetBalance(currency string) (balance Balance, err error) {
-	r, err := b.client.do("GET", "payment/balance", nil, true)
+	r, err := b.do(CategoryTrading, "GET", "payment/balance", nil, true)
 	if err != nil {
 		return
 	}
@@ -234,12 +262,15 @@ func (b *HitBtc) GetBalance(currency string) (balance Balance, err error) {
 
 // GetTrades used to retrieve your trade history.
 // market string literal for the market (ie. BTC/LTC). If set to "all", will return for all market
-func (b *HitBtc) GetTrades(currencyPair string) (trades []Trade, err error) {
+func (b *HitBtc) GetTrades(currencyPair string, opts ...Option) (trades []Trade, err error) {
 	payload := make(map[string]string)
 	if currencyPair != "all" {
 		payload["symbol"] = currencyPair
 	}
-	r, err := b.client.do("GET", "history/trades", payload, true)
+	for _, opt := range opts {
+		opt(payload)
+	}
+	r, err := b.do(CategoryHistory, "GET", "history/trades", payload, true)
 	if err != nil {
 		return
 	}
@@ -254,26 +285,15 @@ func (b *HitBtc) GetTrades(currencyPair string) (trades []Trade, err error) {
 	return
 }
 
-// GetTransactions is used to retrieve your withdrawal and deposit history
-// "Start" and "end" are given in UNIX timestamp format in miliseconds and used to specify the date range for the data returned.
-func (b *HitBtc) GetTransactions(start uint64, end uint64, limit uint32) (transactions []Transaction, err error) {
+// GetTransactions is used to retrieve your withdrawal and deposit history.
+// Use WithFrom/WithTill to restrict the date range and WithLimit/WithOffset
+// to page through results.
+func (b *HitBtc) GetTransactions(opts ...Option) (transactions []Transaction, err error) {
 	payload := make(map[string]string)
-	if start > 0 {
-		payload["from"] = strconv.FormatUint(uint64(start), 10)
+	for _, opt := range opts {
+		opt(payload)
 	}
-	if end == 0 {
-		end = uint64(time.Now().Unix()) * 1000
-	}
-	if end > 0 {
-		payload["till"] = strconv.FormatUint(uint64(end), 10)
-	}
-	if limit > 1000 {
-		limit = 1000
-	}
-	if limit > 0 {
-		payload["limit"] = strconv.FormatUint(uint64(limit), 10)
-	}
-	r, err := b.client.do("GET", "account/transactions", payload, true)
+	r, err := b.do(CategoryHistory, "GET", "account/transactions", payload, true)
 	if err != nil {
 		return
 	}
@@ -296,51 +316,54 @@ type Order struct {
 	Status        string    `json:"status"`
 	Type          string    `json:"type"`
 	TimeInForce   string    `json:"timeInForce"`
-	Price         float64   `json:"price"`
-	StopPrice     float64   `json:"stopPrice"`
-	Quantity      float64   `json:"quantity"`
-	CumQuantity   float64   `json:"cumQuantity"`
+	Price         Decimal   `json:"price"`
+	StopPrice     Decimal   `json:"stopPrice"`
+	Quantity      Decimal   `json:"quantity"`
+	CumQuantity   Decimal   `json:"cumQuantity"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 	ExpireTime    time.Time `json:"expireTime"`
 }
 
 type CreateOrderResponse struct {
-	ID            string `json:"id"`
-	ClientOrderID string `json:"clientOrderId"`
-	Symbol        string `json:"symbol"`
-	Side          string `json:"side"`
-	Status        string `json:"status"`
-	Type          string `json:"type"`
-	TimeInForce   string `json:"timeInForce"`
-	Price         string `json:"price"`
-	StopPrice     string `json:"stopPrice"`
-	Quantity      string `json:"quantity"`
-	CumQuantity   string `json:"cumQuantity"`
-	CreatedAt     string `json:"createdAt"`
-	UpdatedAt     string `json:"updatedAt"`
-	ExpireTime    string `json:"expireTime"`
+	ID            string  `json:"id"`
+	ClientOrderID string  `json:"clientOrderId"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	Status        string  `json:"status"`
+	Type          string  `json:"type"`
+	TimeInForce   string  `json:"timeInForce"`
+	Price         Decimal `json:"price"`
+	StopPrice     Decimal `json:"stopPrice"`
+	Quantity      Decimal `json:"quantity"`
+	CumQuantity   Decimal `json:"cumQuantity"`
+	CreatedAt     string  `json:"createdAt"`
+	UpdatedAt     string  `json:"updatedAt"`
+	ExpireTime    string  `json:"expireTime"`
 }
 
-func (b *HitBtc) CreateOrder(o Order) (order CreateOrderResponse, err error) {
+// CreateOrder places a new order for quantity of symbol. Use the With*
+// options to set the order type, price, time in force, and other
+// parameters that used to be keyed off zero-value struct fields.
+func (b *HitBtc) CreateOrder(symbol, side string, quantity Decimal, opts ...Option) (order CreateOrderResponse, err error) {
+	return b.createOrder(orderEndpoint, symbol, side, quantity, opts...)
+}
+
+// createOrder is the shared implementation behind CreateOrder and
+// CreateMarginOrder. endpoint is passed explicitly rather than read off
+// shared state, so concurrent spot and margin order calls on the same
+// *HitBtc can't race each other onto the wrong endpoint.
+func (b *HitBtc) createOrder(endpoint, symbol, side string, quantity Decimal, opts ...Option) (order CreateOrderResponse, err error) {
 	payload := make(map[string]string)
 
-	payload["symbol"] = o.Symbol
-	payload["side"] = o.Side
-	payload["quantity"] = strconv.FormatFloat(o.Quantity, 'f', -1, 64)
-	if o.Type != "" {
-		payload["type"] = o.Type
-	}
-	if o.TimeInForce != "" {
-		payload["timeInForce"] = o.TimeInForce
+	payload["symbol"] = symbol
+	payload["side"] = side
+	payload["quantity"] = quantity.String()
+	for _, opt := range opts {
+		opt(payload)
 	}
-	if o.Price > 0 {
-		payload["price"] = strconv.FormatFloat(o.Price, 'f', -1, 64)
-	}
-
-	// log.Print(payload)
 
-	r, err := b.client.do("POST", "order", payload, true)
+	r, err := b.do(CategoryTrading, "POST", endpoint, payload, true)
 
 	if err != nil {
 		e := ErrorResponse{}
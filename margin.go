@@ -0,0 +1,101 @@
+package hitbtc
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Order types accepted by WithType for CreateOrder and CreateMarginOrder,
+// including the stop and take-profit variants.
+const (
+	OrderTypeLimit            = "limit"
+	OrderTypeMarket           = "market"
+	OrderTypeStopLimit        = "stopLimit"
+	OrderTypeStopMarket       = "stopMarket"
+	OrderTypeTakeProfitLimit  = "takeProfitLimit"
+	OrderTypeTakeProfitMarket = "takeProfitMarket"
+)
+
+// Order endpoints, passed explicitly through createOrder rather than
+// toggled on shared HitBtc state, so a spot and a margin order placed
+// concurrently on the same client can never race onto each other's
+// endpoint.
+const (
+	orderEndpoint       = "order"
+	marginOrderEndpoint = "margin/order"
+)
+
+// MarginAccount is a single currency's margin account, as returned by
+// GetMarginAccounts.
+type MarginAccount struct {
+	Currency         string  `json:"currency"`
+	Symbol           string  `json:"symbol"`
+	Leverage         string  `json:"leverage"`
+	Available        Decimal `json:"available"`
+	Reserved         Decimal `json:"reserved"`
+	LiquidationPrice Decimal `json:"liquidationPrice"`
+}
+
+// GetMarginAccounts retrieves all margin accounts on the current account.
+func (b *HitBtc) GetMarginAccounts() (accounts []MarginAccount, err error) {
+	r, err := b.do(CategoryTrading, "GET", "margin/account", nil, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	err = json.Unmarshal(r, &accounts)
+	return
+}
+
+// AccountType identifies a HitBTC account that internal transfers can move
+// funds between.
+type AccountType string
+
+const (
+	AccountSpot   AccountType = "spot"
+	AccountMargin AccountType = "margin"
+)
+
+// TransferBetweenAccounts moves amount of currency from one account to
+// another (ex: AccountSpot to AccountMargin).
+func (b *HitBtc) TransferBetweenAccounts(from, to AccountType, currency string, amount Decimal) (err error) {
+	payload := map[string]string{
+		"currency": strings.ToUpper(currency),
+		"amount":   amount.String(),
+		"from":     string(from),
+		"to":       string(to),
+	}
+	r, err := b.do(CategoryTrading, "POST", "account/transfer", payload, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	return handleErr(response)
+}
+
+// CreateMarginOrder places quantity of symbol on the margin order book.
+func (b *HitBtc) CreateMarginOrder(symbol, side string, quantity Decimal, opts ...Option) (order CreateOrderResponse, err error) {
+	return b.createOrder(marginOrderEndpoint, symbol, side, quantity, opts...)
+}
+
+// CloseMarginPosition closes the open margin position for symbol.
+func (b *HitBtc) CloseMarginPosition(symbol string) (err error) {
+	r, err := b.do(CategoryTrading, "DELETE", "margin/position/"+strings.ToUpper(symbol), nil, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	return handleErr(response)
+}
@@ -0,0 +1,146 @@
+package hitbtc
+
+import (
+	"sync"
+	"time"
+)
+
+// MarketDataProvider is the subset of public market data HitBtc exposes,
+// abstracted so a caller can swap in a fallback source when HitBTC itself
+// is unavailable.
+type MarketDataProvider interface {
+	GetTicker(symbol string) (Ticker, error)
+	GetCandles(symbol string, period string) ([]Candle, error)
+	GetSymbols() ([]Symbol, error)
+}
+
+// hitBtcProvider adapts HitBtc's own REST methods to MarketDataProvider.
+type hitBtcProvider struct {
+	client *HitBtc
+}
+
+// NewHitBtcProvider wraps b as a MarketDataProvider, for use as
+// PrimaryWithFallback's primary source.
+func NewHitBtcProvider(b *HitBtc) MarketDataProvider {
+	return &hitBtcProvider{client: b}
+}
+
+func (p *hitBtcProvider) GetTicker(symbol string) (Ticker, error) {
+	// Calls the REST-only path, not p.client.GetTicker: that method
+	// routes through DataProvider when set, which for a primary built
+	// with NewHitBtcProvider is this very PrimaryWithFallback — calling
+	// it here would recurse until the stack overflows.
+	return p.client.getTickerREST(symbol)
+}
+
+func (p *hitBtcProvider) GetCandles(symbol string, period string) ([]Candle, error) {
+	return p.client.getCandlesREST(symbol, period)
+}
+
+func (p *hitBtcProvider) GetSymbols() ([]Symbol, error) {
+	return p.client.GetSymbols()
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// PrimaryWithFallback wraps a primary MarketDataProvider (normally HitBTC
+// itself, via NewHitBtcProvider) and falls back to a secondary source
+// whenever the primary returns an error, so long-running strategies
+// survive a HitBTC outage or a delisted symbol without code changes. A
+// small TTL cache sits in front of both so repeated calls for the same
+// symbol within the window don't hit either source again.
+type PrimaryWithFallback struct {
+	Primary   MarketDataProvider
+	Secondary MarketDataProvider
+	TTL       time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewPrimaryWithFallback returns a PrimaryWithFallback that caches
+// responses for ttl before re-querying primary/secondary.
+func NewPrimaryWithFallback(primary, secondary MarketDataProvider, ttl time.Duration) *PrimaryWithFallback {
+	return &PrimaryWithFallback{
+		Primary:   primary,
+		Secondary: secondary,
+		TTL:       ttl,
+		cache:     make(map[string]cacheEntry),
+	}
+}
+
+func (p *PrimaryWithFallback) cached(key string) (interface{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (p *PrimaryWithFallback) store(key string, value interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[key] = cacheEntry{value: value, expires: time.Now().Add(p.TTL)}
+}
+
+// GetTicker returns a cached ticker if one is within TTL, otherwise
+// queries Primary and falls back to Secondary on error.
+func (p *PrimaryWithFallback) GetTicker(symbol string) (Ticker, error) {
+	key := "ticker:" + symbol
+	if v, ok := p.cached(key); ok {
+		return v.(Ticker), nil
+	}
+
+	ticker, err := p.Primary.GetTicker(symbol)
+	if err != nil {
+		if ticker, err = p.Secondary.GetTicker(symbol); err != nil {
+			return Ticker{}, err
+		}
+	}
+
+	p.store(key, ticker)
+	return ticker, nil
+}
+
+// GetCandles returns cached candles if within TTL, otherwise queries
+// Primary and falls back to Secondary on error.
+func (p *PrimaryWithFallback) GetCandles(symbol string, period string) ([]Candle, error) {
+	key := "candles:" + symbol + ":" + period
+	if v, ok := p.cached(key); ok {
+		return v.([]Candle), nil
+	}
+
+	candles, err := p.Primary.GetCandles(symbol, period)
+	if err != nil {
+		if candles, err = p.Secondary.GetCandles(symbol, period); err != nil {
+			return nil, err
+		}
+	}
+
+	p.store(key, candles)
+	return candles, nil
+}
+
+// GetSymbols returns cached symbols if within TTL, otherwise queries
+// Primary and falls back to Secondary on error.
+func (p *PrimaryWithFallback) GetSymbols() ([]Symbol, error) {
+	const key = "symbols"
+	if v, ok := p.cached(key); ok {
+		return v.([]Symbol), nil
+	}
+
+	symbols, err := p.Primary.GetSymbols()
+	if err != nil {
+		if symbols, err = p.Secondary.GetSymbols(); err != nil {
+			return nil, err
+		}
+	}
+
+	p.store(key, symbols)
+	return symbols, nil
+}
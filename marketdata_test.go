@@ -0,0 +1,70 @@
+package hitbtc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	tickerCalls int
+	err         error
+	ticker      Ticker
+}
+
+func (f *fakeProvider) GetTicker(symbol string) (Ticker, error) {
+	f.tickerCalls++
+	return f.ticker, f.err
+}
+
+func (f *fakeProvider) GetCandles(symbol string, period string) ([]Candle, error) {
+	return nil, f.err
+}
+
+func (f *fakeProvider) GetSymbols() ([]Symbol, error) {
+	return nil, f.err
+}
+
+func TestPrimaryWithFallbackFallsBackOnPrimaryError(t *testing.T) {
+	primary := &fakeProvider{err: errors.New("primary down")}
+	secondary := &fakeProvider{ticker: Ticker{Symbol: "BTCUSD"}}
+
+	p := NewPrimaryWithFallback(primary, secondary, time.Minute)
+
+	ticker, err := p.GetTicker("BTCUSD")
+	if err != nil {
+		t.Fatalf("expected fallback to secondary to succeed, got err: %v", err)
+	}
+	if ticker.Symbol != "BTCUSD" {
+		t.Fatalf("got ticker from wrong source: %+v", ticker)
+	}
+}
+
+func TestPrimaryWithFallbackCachesWithinTTL(t *testing.T) {
+	primary := &fakeProvider{ticker: Ticker{Symbol: "ETHUSD"}}
+	secondary := &fakeProvider{err: errors.New("should not be called")}
+
+	p := NewPrimaryWithFallback(primary, secondary, time.Minute)
+
+	if _, err := p.GetTicker("ETHUSD"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if _, err := p.GetTicker("ETHUSD"); err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+
+	if primary.tickerCalls != 1 {
+		t.Fatalf("expected primary to be queried once within TTL, got %d calls", primary.tickerCalls)
+	}
+}
+
+func TestPrimaryWithFallbackReturnsErrorWhenBothFail(t *testing.T) {
+	primary := &fakeProvider{err: errors.New("primary down")}
+	secondary := &fakeProvider{err: errors.New("secondary down too")}
+
+	p := NewPrimaryWithFallback(primary, secondary, time.Minute)
+
+	if _, err := p.GetTicker("BTCUSD"); err == nil {
+		t.Fatal("expected an error when both primary and secondary fail")
+	}
+}
@@ -0,0 +1,120 @@
+package hitbtc
+
+import (
+	"strconv"
+	"time"
+)
+
+// Option is a functional parameter applied to a request payload. It follows
+// the same optional-parameter pattern used by REST methods that accept a
+// variable set of query/form parameters, without requiring their signatures
+// to change as HitBTC adds more of them.
+type Option func(payload map[string]string)
+
+// TimeInForce controls how long an order remains active.
+type TimeInForce string
+
+const (
+	TimeInForceGTC TimeInForce = "GTC" // Good Till Cancel
+	TimeInForceIOC TimeInForce = "IOC" // Immediate Or Cancel
+	TimeInForceFOK TimeInForce = "FOK" // Fill Or Kill
+	TimeInForceDay TimeInForce = "Day"
+	TimeInForceGTD TimeInForce = "GTD" // Good Till Date, pair with WithExpireTime
+)
+
+// SortOrder controls the ordering of history results.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "ASC"
+	SortDesc SortOrder = "DESC"
+)
+
+// WithClientOrderID sets a caller-supplied identifier for an order.
+func WithClientOrderID(id string) Option {
+	return func(payload map[string]string) {
+		payload["clientOrderId"] = id
+	}
+}
+
+// WithType sets the order type (ex: "limit", "market", "stopLimit").
+func WithType(orderType string) Option {
+	return func(payload map[string]string) {
+		payload["type"] = orderType
+	}
+}
+
+// WithPrice sets the order price. Required for limit and stop-limit orders.
+func WithPrice(price Decimal) Option {
+	return func(payload map[string]string) {
+		payload["price"] = price.String()
+	}
+}
+
+// WithStopPrice sets the trigger price for stop and take-profit orders.
+func WithStopPrice(price Decimal) Option {
+	return func(payload map[string]string) {
+		payload["stopPrice"] = price.String()
+	}
+}
+
+// WithPostOnly marks an order as post-only, rejecting it rather than
+// letting it take liquidity.
+func WithPostOnly() Option {
+	return func(payload map[string]string) {
+		payload["postOnly"] = "true"
+	}
+}
+
+// WithTimeInForce sets the order's time in force.
+func WithTimeInForce(tif TimeInForce) Option {
+	return func(payload map[string]string) {
+		payload["timeInForce"] = string(tif)
+	}
+}
+
+// WithExpireTime sets the expiration time for a GTD order.
+func WithExpireTime(t time.Time) Option {
+	return func(payload map[string]string) {
+		payload["expireTime"] = t.UTC().Format(time.RFC3339)
+	}
+}
+
+// WithFrom restricts results to records at or after t. HitBTC interprets
+// "from"/"till" as ISO-8601 datetimes unless the request also sets
+// by=id, so this is encoded the same way as WithExpireTime, not as a
+// Unix timestamp.
+func WithFrom(t time.Time) Option {
+	return func(payload map[string]string) {
+		payload["from"] = t.UTC().Format(time.RFC3339)
+	}
+}
+
+// WithTill restricts results to records before t. See WithFrom for the
+// encoding.
+func WithTill(t time.Time) Option {
+	return func(payload map[string]string) {
+		payload["till"] = t.UTC().Format(time.RFC3339)
+	}
+}
+
+// WithLimit caps the number of records returned.
+func WithLimit(limit int) Option {
+	return func(payload map[string]string) {
+		payload["limit"] = strconv.Itoa(limit)
+	}
+}
+
+// WithOffset skips the first offset records.
+func WithOffset(offset int) Option {
+	return func(payload map[string]string) {
+		payload["offset"] = strconv.Itoa(offset)
+	}
+}
+
+// WithSort sets the sort order of returned records.
+func WithSort(order SortOrder) Option {
+	return func(payload map[string]string) {
+		payload["sort"] = string(order)
+	}
+}
@@ -0,0 +1,64 @@
+package hitbtc
+
+import (
+	"testing"
+	"time"
+)
+
+func applyOptions(opts ...Option) map[string]string {
+	payload := make(map[string]string)
+	for _, opt := range opts {
+		opt(payload)
+	}
+	return payload
+}
+
+func TestOptionBuilders(t *testing.T) {
+	when := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+
+	payload := applyOptions(
+		WithClientOrderID("my-id"),
+		WithType(OrderTypeStopLimit),
+		WithPrice(DecimalFromFloat(1.5)),
+		WithStopPrice(DecimalFromFloat(1.4)),
+		WithPostOnly(),
+		WithTimeInForce(TimeInForceGTD),
+		WithExpireTime(when),
+		WithLimit(50),
+		WithOffset(10),
+		WithSort(SortDesc),
+	)
+
+	want := map[string]string{
+		"clientOrderId": "my-id",
+		"type":          "stopLimit",
+		"price":         "1.5",
+		"stopPrice":     "1.4",
+		"postOnly":      "true",
+		"timeInForce":   "GTD",
+		"expireTime":    "2026-07-26T12:00:00Z",
+		"limit":         "50",
+		"offset":        "10",
+		"sort":          "DESC",
+	}
+
+	for key, wantValue := range want {
+		if got := payload[key]; got != wantValue {
+			t.Errorf("payload[%q] = %q, want %q", key, got, wantValue)
+		}
+	}
+}
+
+func TestWithFromAndWithTillUseRFC3339(t *testing.T) {
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	payload := applyOptions(WithFrom(when), WithTill(when))
+
+	const want = "2026-01-02T03:04:05Z"
+	if payload["from"] != want {
+		t.Errorf("from = %q, want %q (RFC3339, matching WithExpireTime's encoding)", payload["from"], want)
+	}
+	if payload["till"] != want {
+		t.Errorf("till = %q, want %q (RFC3339, matching WithExpireTime's encoding)", payload["till"], want)
+	}
+}
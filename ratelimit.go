@@ -0,0 +1,124 @@
+package hitbtc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// LimiterCategory groups endpoints that share one of HitBTC's published
+// rate limits.
+type LimiterCategory int
+
+const (
+	CategoryPublic LimiterCategory = iota
+	CategoryMarketData
+	CategoryTrading
+	CategoryHistory
+)
+
+// RateLimits holds the per-category limiters and retry policy used by do.
+// The zero value has no limiters and zero retries, so HitBtc values built
+// with New continue to make unthrottled requests exactly as before.
+type RateLimits struct {
+	limiters   map[LimiterCategory]*rate.Limiter
+	maxRetries int
+}
+
+// defaultRateLimits mirrors HitBTC's published limits: 100 req/s public,
+// 300 req/s trading, 10 req/s history. Market data endpoints get their own
+// limiter at the public rate, since HitBTC doesn't document a separate one.
+func defaultRateLimits() RateLimits {
+	return RateLimits{
+		limiters: map[LimiterCategory]*rate.Limiter{
+			CategoryPublic:     rate.NewLimiter(100, 100),
+			CategoryMarketData: rate.NewLimiter(100, 100),
+			CategoryTrading:    rate.NewLimiter(300, 300),
+			CategoryHistory:    rate.NewLimiter(10, 10),
+		},
+		maxRetries: 3,
+	}
+}
+
+// NewWithRateLimits returns an instantiated HitBTC struct that throttles
+// requests to HitBTC's published per-category limits and retries 429s
+// with exponential backoff, up to maxRetries times.
+func NewWithRateLimits(apiKey, apiSecret string, maxRetries int) *HitBtc {
+	client := NewClient(apiKey, apiSecret)
+	limits := defaultRateLimits()
+	limits.maxRetries = maxRetries
+	return &HitBtc{client: client, RateLimits: limits}
+}
+
+// do applies category's rate limit (if any), calls the underlying client,
+// and retries on a 429 response with exponential backoff.
+func (b *HitBtc) do(category LimiterCategory, method, path string, payload map[string]string, signed bool) ([]byte, error) {
+	limiter := b.RateLimits.limiters[category]
+
+	var r []byte
+	var err error
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if waitErr := limiter.Wait(context.Background()); waitErr != nil {
+				return nil, waitErr
+			}
+		}
+
+		r, err = b.client.do(method, path, payload, signed)
+		if !isRateLimited(r, err) {
+			return r, err
+		}
+		if attempt >= b.RateLimits.maxRetries {
+			return r, fmt.Errorf("hitbtc: rate limited after %d retries: %w", attempt, err)
+		}
+		time.Sleep(backoff(attempt, err))
+	}
+}
+
+// statusCoder is implemented by client errors that can report the HTTP
+// status they came from, so a bare 429 (no HitBTC JSON error body) is
+// still recognized as a rate limit.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// retryAfterProvider is implemented by client errors that can report a
+// server-supplied Retry-After delay, parsed from the response header.
+type retryAfterProvider interface {
+	RetryAfter() (time.Duration, bool)
+}
+
+// isRateLimited reports whether a response represents a HitBTC 429,
+// whether that's surfaced as the HTTP status itself, the error code the
+// API embeds in its JSON body, or plain text mentioning 429.
+func isRateLimited(body []byte, err error) bool {
+	if err == nil {
+		return false
+	}
+	if sc, ok := err.(statusCoder); ok {
+		return sc.StatusCode() == http.StatusTooManyRequests
+	}
+	var e ErrorResponse
+	if jsonErr := json.Unmarshal(body, &e); jsonErr == nil && e.Error.Code == http.StatusTooManyRequests {
+		return true
+	}
+	return strings.Contains(err.Error(), "429")
+}
+
+// backoff returns the delay before the next retry: the server-supplied
+// Retry-After duration if err carries one, otherwise exponential backoff
+// from 500ms.
+func backoff(attempt int, err error) time.Duration {
+	if rap, ok := err.(retryAfterProvider); ok {
+		if d, ok := rap.RetryAfter(); ok && d > 0 {
+			return d
+		}
+	}
+	return time.Duration(math.Pow(2, float64(attempt))) * 500 * time.Millisecond
+}
@@ -0,0 +1,69 @@
+package hitbtc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type statusCodeError struct {
+	code int
+}
+
+func (e *statusCodeError) Error() string { return "http error" }
+func (e *statusCodeError) StatusCode() int {
+	return e.code
+}
+
+type retryAfterError struct {
+	statusCodeError
+	after time.Duration
+}
+
+func (e *retryAfterError) RetryAfter() (time.Duration, bool) {
+	return e.after, true
+}
+
+func TestIsRateLimitedDetectsBareHTTPStatus(t *testing.T) {
+	err := &statusCodeError{code: 429}
+	if !isRateLimited(nil, err) {
+		t.Fatal("expected a bare HTTP 429 status to be detected as rate limited")
+	}
+}
+
+func TestIsRateLimitedIgnoresOtherStatuses(t *testing.T) {
+	err := &statusCodeError{code: 500}
+	if isRateLimited(nil, err) {
+		t.Fatal("a 500 should not be treated as rate limited")
+	}
+}
+
+func TestIsRateLimitedDetectsJSONErrorCode(t *testing.T) {
+	err := errors.New("request failed")
+	body := []byte(`{"error":{"code":429,"message":"Too many requests"}}`)
+	if !isRateLimited(body, err) {
+		t.Fatal("expected a JSON error.code of 429 to be detected as rate limited")
+	}
+}
+
+func TestIsRateLimitedNoErrorIsNotRateLimited(t *testing.T) {
+	if isRateLimited([]byte(`{}`), nil) {
+		t.Fatal("a nil error should never be treated as rate limited")
+	}
+}
+
+func TestBackoffHonorsRetryAfter(t *testing.T) {
+	err := &retryAfterError{statusCodeError: statusCodeError{code: 429}, after: 3 * time.Second}
+	if got := backoff(0, err); got != 3*time.Second {
+		t.Fatalf("got %v, want the server-supplied Retry-After of 3s", got)
+	}
+}
+
+func TestBackoffFallsBackToExponential(t *testing.T) {
+	err := errors.New("rate limited")
+	d0 := backoff(0, err)
+	d1 := backoff(1, err)
+	if d1 <= d0 {
+		t.Fatalf("expected backoff to grow with attempt, got %v then %v", d0, d1)
+	}
+}
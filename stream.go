@@ -0,0 +1,492 @@
+package hitbtc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsEndpoint = "wss://api.hitbtc.com/api/2/ws"
+
+	wsReconnectDelay = 5 * time.Second
+	wsPingInterval   = 30 * time.Second
+)
+
+// Stream is a WebSocket client for HitBTC's streaming API. It mirrors the
+// subscription surface of HitBtc's REST methods, but delivers updates on
+// typed channels instead of one-shot responses, and reconnects (with
+// resubscription) transparently when the connection drops.
+type Stream struct {
+	apiKey    string
+	apiSecret string
+	debug     bool
+
+	mu       sync.Mutex
+	writeMu  sync.Mutex
+	conn     *websocket.Conn
+	nextID   uint64
+	loopDone chan struct{}
+
+	subs     map[subKey]*subscription
+	handlers map[subKey]chan json.RawMessage
+	closers  []func()
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// subKey identifies one subscription by its JSON-RPC method and, for
+// per-symbol channels, the symbol it was opened for. HitBTC sends every
+// symbol's updates under the same notification method (ex: all orderbook
+// updates arrive as "updateOrderbook"), so the method alone isn't enough
+// to route a notification back to the subscriber that asked for it.
+type subKey struct {
+	method string
+	symbol string
+}
+
+type subscription struct {
+	method string
+	symbol string
+	params map[string]interface{}
+}
+
+// notificationMethods maps each subscribe request to the notification
+// method name(s) HitBTC actually sends updates under, which are not the
+// same string as the subscribe request itself (ex: "subscribeTicker"
+// subscribes, but updates arrive with method "ticker").
+var notificationMethods = map[string][]string{
+	"subscribeTicker":    {"ticker"},
+	"subscribeOrderbook": {"snapshotOrderbook", "updateOrderbook"},
+	"subscribeTrades":    {"snapshotTrades", "updateTrades"},
+	"subscribeCandles":   {"snapshotCandles", "updateCandles"},
+	"subscribeReports":   {"activeOrders", "report"},
+}
+
+type wsRequest struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params,omitempty"`
+	ID     uint64                 `json:"id,omitempty"`
+}
+
+type wsResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	} `json:"error"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// NewStream returns a Stream ready to Connect. apiKey/apiSecret may be empty
+// for public-only usage; they are required for SubscribeReports.
+func NewStream(apiKey, apiSecret string) *Stream {
+	return &Stream{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		subs:      make(map[subKey]*subscription),
+		handlers:  make(map[subKey]chan json.RawMessage),
+		loopDone:  make(chan struct{}),
+	}
+}
+
+// SetDebug enables/disables logging of the raw WebSocket traffic.
+func (s *Stream) SetDebug(enable bool) {
+	s.debug = enable
+}
+
+// Connect dials the HitBTC WebSocket endpoint and starts the read/reconnect
+// loop. It returns once the initial connection succeeds; subsequent drops
+// are retried transparently until ctx is canceled or Close is called.
+func (s *Stream) Connect(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	if err := s.dial(); err != nil {
+		// No loop was started, so leave ctx unset: Close then doesn't
+		// wait on a loopDone that would never be closed.
+		s.cancel()
+		s.ctx, s.cancel = nil, nil
+		return err
+	}
+
+	go s.loop()
+	return nil
+}
+
+// Close terminates the connection, stops the reconnect loop, and closes
+// every delivery channel returned by a SubscribeX call (and the internal
+// buffered channels feeding them), so a consumer ranging over one of them
+// sees it close instead of blocking forever.
+func (s *Stream) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.mu.Lock()
+	conn := s.conn
+	started := s.ctx != nil
+	s.mu.Unlock()
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+
+	if started {
+		// loop only exits (and closes loopDone) once it observes ctx
+		// canceled or the conn we just closed failing to read, so it's
+		// safe to wait on: dispatch can't still be running after this,
+		// meaning nothing can write to a handler's channel once we close
+		// it below.
+		<-s.loopDone
+	}
+
+	s.mu.Lock()
+	closers := s.closers
+	s.closers = nil
+	s.mu.Unlock()
+	for _, closeSub := range closers {
+		closeSub()
+	}
+
+	return err
+}
+
+func (s *Stream) dial() error {
+	conn, _, err := websocket.DefaultDialer.Dial(wsEndpoint, nil)
+	if err != nil {
+		return fmt.Errorf("hitbtc: dial ws: %w", err)
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	if s.apiKey != "" {
+		if err := s.login(); err != nil {
+			return err
+		}
+	}
+
+	// send takes s.mu itself, so copy the subscriptions out before
+	// resending them.
+	s.mu.Lock()
+	subs := make([]*subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.mu.Unlock()
+	for _, sub := range subs {
+		s.send(sub.method, sub.params)
+	}
+
+	go s.ping(conn)
+
+	return nil
+}
+
+// ping keeps conn alive until it is replaced or closed.
+func (s *Stream) ping(conn *websocket.Conn) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			current := s.conn == conn
+			s.mu.Unlock()
+			if !current {
+				return
+			}
+			s.send("ping", nil)
+		}
+	}
+}
+
+// loop reads frames off the current connection, dispatches them, and
+// reconnects whenever the read fails. It closes loopDone on return so
+// Close can wait for dispatch to be done running before it tears down
+// subscriber channels.
+func (s *Stream) loop() {
+	defer close(s.loopDone)
+
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			if s.debug {
+				log.Printf("hitbtc: ws read error: %v", err)
+			}
+			if !s.reconnect() {
+				return
+			}
+			continue
+		}
+
+		s.dispatch(data)
+	}
+}
+
+// reconnect blocks until a new connection is established or ctx is
+// canceled, in which case it returns false.
+func (s *Stream) reconnect() bool {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return false
+		case <-time.After(wsReconnectDelay):
+		}
+		if err := s.dial(); err == nil {
+			return true
+		}
+	}
+}
+
+// dispatch routes a notification to the delivery channel of the
+// subscription that asked for its (method, symbol) pair — HitBTC sends
+// every symbol's updates for a channel under the same method name (ex:
+// all orderbook updates arrive as "updateOrderbook"), so the symbol
+// embedded in params is what actually demuxes concurrent subscriptions.
+// A notification whose (method, symbol) pair has no subscriber falls back
+// to the empty-symbol key, which is what subscribe registers for reports:
+// a single "report" notification carries its order's symbol, while the
+// "activeOrders" snapshot carries none.
+//
+// dispatch never blocks: a subscription whose per-subscription goroutine
+// (started in subscribe) is behind has its update dropped rather than
+// stalling the read loop, so one slow consumer can't stall pings,
+// reconnects, or every other subscription.
+func (s *Stream) dispatch(data []byte) {
+	if s.debug {
+		log.Printf("hitbtc: ws recv: %s", data)
+	}
+
+	var resp wsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return
+	}
+
+	if resp.Method == "" {
+		return
+	}
+
+	key := subKey{method: resp.Method, symbol: notificationSymbol(resp.Params)}
+
+	s.mu.Lock()
+	raw, ok := s.handlers[key]
+	if !ok {
+		raw, ok = s.handlers[subKey{method: resp.Method}]
+	}
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case raw <- resp.Params:
+	default:
+		if s.debug {
+			log.Printf("hitbtc: dropping %s update for %q, consumer is behind", resp.Method, key.symbol)
+		}
+	}
+}
+
+// notificationSymbol extracts the "symbol" field HitBTC includes on every
+// per-symbol notification. It returns "" for notifications with no symbol
+// (ex: the "activeOrders" snapshot, whose params are an array).
+func notificationSymbol(params json.RawMessage) string {
+	var p struct {
+		Symbol string `json:"symbol"`
+	}
+	_ = json.Unmarshal(params, &p)
+	return p.Symbol
+}
+
+// send writes a request to the current connection. Writes are serialized
+// on writeMu because gorilla/websocket forbids concurrent writers, and
+// send is called from the read loop's dial/resubscribe path, the ping
+// goroutine, and every SubscribeX call.
+func (s *Stream) send(method string, params map[string]interface{}) {
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.WriteJSON(wsRequest{Method: method, Params: params, ID: id})
+}
+
+// login authenticates the connection via HMAC-SHA256 over a nonce, as
+// required before SubscribeReports will deliver any data.
+func (s *Stream) login() error {
+	nonce := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+	mac := hmac.New(sha256.New, []byte(s.apiSecret))
+	mac.Write([]byte(nonce))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	s.send("login", map[string]interface{}{
+		"algo":      "HS256",
+		"pKey":      s.apiKey,
+		"nonce":     nonce,
+		"signature": signature,
+	})
+	return nil
+}
+
+// subscribe registers channel's subscription for symbol (so dial can
+// resend it after a reconnect, and dispatch can route to it) and starts a
+// dedicated goroutine that drains notifications into handle, decoupling
+// slow per-subscription processing from the shared read loop. symbol is
+// "" for subscriptions with no per-symbol notifications (reports).
+//
+// closeDelivery is called once raw is closed and the drain goroutine has
+// finished delivering everything already queued on it, so it should close
+// the typed channel a SubscribeX method returned to its caller.
+func (s *Stream) subscribe(channel string, symbol string, params map[string]interface{}, handle func(json.RawMessage), closeDelivery func()) {
+	raw := make(chan json.RawMessage, 64)
+	go func() {
+		for msg := range raw {
+			handle(msg)
+		}
+		closeDelivery()
+	}()
+
+	key := subKey{method: channel, symbol: symbol}
+	s.mu.Lock()
+	s.subs[key] = &subscription{method: channel, symbol: symbol, params: params}
+	for _, notification := range notificationMethods[channel] {
+		s.handlers[subKey{method: notification, symbol: symbol}] = raw
+	}
+	s.closers = append(s.closers, func() { close(raw) })
+	s.mu.Unlock()
+
+	s.send(channel, params)
+}
+
+// SubscribeTicker subscribes to ticker updates for symbol and returns a
+// channel delivering each update.
+func (s *Stream) SubscribeTicker(symbol string) <-chan Ticker {
+	ch := make(chan Ticker, 16)
+	s.subscribe("subscribeTicker", symbol, map[string]interface{}{"symbol": symbol}, func(raw json.RawMessage) {
+		var t Ticker
+		if err := json.Unmarshal(raw, &t); err == nil {
+			ch <- t
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// OrderBook is a snapshot or incremental update of a symbol's order book.
+type OrderBook struct {
+	Ask      []OrderBookLevel `json:"ask"`
+	Bid      []OrderBookLevel `json:"bid"`
+	Symbol   string           `json:"symbol"`
+	Sequence uint64           `json:"sequence"`
+}
+
+// OrderBookLevel is a single price level in an order book update.
+type OrderBookLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// SubscribeOrderbook subscribes to order book snapshots and incremental
+// updates for symbol.
+func (s *Stream) SubscribeOrderbook(symbol string) <-chan OrderBook {
+	ch := make(chan OrderBook, 16)
+	s.subscribe("subscribeOrderbook", symbol, map[string]interface{}{"symbol": symbol}, func(raw json.RawMessage) {
+		var ob OrderBook
+		if err := json.Unmarshal(raw, &ob); err == nil {
+			ch <- ob
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// SubscribeTrades subscribes to the live trade feed for symbol.
+func (s *Stream) SubscribeTrades(symbol string) <-chan []Trade {
+	ch := make(chan []Trade, 16)
+	s.subscribe("subscribeTrades", symbol, map[string]interface{}{"symbol": symbol, "limit": 100}, func(raw json.RawMessage) {
+		var params struct {
+			Data   []Trade `json:"data"`
+			Symbol string  `json:"symbol"`
+		}
+		if err := json.Unmarshal(raw, &params); err == nil {
+			ch <- params.Data
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// SubscribeCandles subscribes to OHLC candle updates for symbol at the
+// given period (ex: "M1", "H1", "D1").
+func (s *Stream) SubscribeCandles(symbol string, period string) <-chan []Candle {
+	ch := make(chan []Candle, 16)
+	s.subscribe("subscribeCandles", symbol, map[string]interface{}{"symbol": symbol, "period": period}, func(raw json.RawMessage) {
+		var params struct {
+			Data   []Candle `json:"data"`
+			Symbol string   `json:"symbol"`
+			Period string   `json:"period"`
+		}
+		if err := json.Unmarshal(raw, &params); err == nil {
+			ch <- params.Data
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// Report is an order/trade update delivered to authenticated clients via
+// SubscribeReports.
+type Report struct {
+	Order
+	ReportType string `json:"reportType"`
+}
+
+// SubscribeReports subscribes to order and trade report updates for the
+// authenticated account. The Stream must have been created by NewStream
+// with non-empty credentials for this to deliver any data.
+func (s *Stream) SubscribeReports() <-chan Report {
+	ch := make(chan Report, 16)
+	s.subscribe("subscribeReports", "", nil, func(raw json.RawMessage) {
+		var reports []Report
+		if err := json.Unmarshal(raw, &reports); err == nil {
+			for _, rep := range reports {
+				ch <- rep
+			}
+			return
+		}
+		var r Report
+		if err := json.Unmarshal(raw, &r); err == nil {
+			ch <- r
+		}
+	}, func() { close(ch) })
+	return ch
+}
@@ -0,0 +1,120 @@
+package hitbtc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStreamDispatchRoutesNotificationToSubscriber(t *testing.T) {
+	s := NewStream("", "")
+
+	received := make(chan json.RawMessage, 1)
+	s.subscribe("subscribeTicker", "BTCUSD", map[string]interface{}{"symbol": "BTCUSD"}, func(raw json.RawMessage) {
+		received <- raw
+	}, func() {})
+
+	s.dispatch([]byte(`{"method":"ticker","params":{"symbol":"BTCUSD"}}`))
+
+	select {
+	case raw := <-received:
+		var params struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := json.Unmarshal(raw, &params); err != nil {
+			t.Fatalf("unmarshal delivered params: %v", err)
+		}
+		if params.Symbol != "BTCUSD" {
+			t.Fatalf("got symbol %q, want BTCUSD", params.Symbol)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ticker notification was never delivered to the subscriber")
+	}
+}
+
+func TestStreamDispatchIgnoresUnknownMethod(t *testing.T) {
+	s := NewStream("", "")
+
+	received := make(chan json.RawMessage, 1)
+	s.subscribe("subscribeTicker", "BTCUSD", map[string]interface{}{"symbol": "BTCUSD"}, func(raw json.RawMessage) {
+		received <- raw
+	}, func() {})
+
+	// "subscribeTicker" itself is never the notification method HitBTC
+	// sends, so dispatching it back should not reach the subscriber.
+	s.dispatch([]byte(`{"method":"subscribeTicker","params":{}}`))
+
+	select {
+	case <-received:
+		t.Fatal("dispatch delivered a notification for an unmapped method")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStreamDispatchRoutesBySymbol(t *testing.T) {
+	s := NewStream("", "")
+
+	btc := make(chan json.RawMessage, 1)
+	eth := make(chan json.RawMessage, 1)
+	s.subscribe("subscribeTicker", "BTCUSD", map[string]interface{}{"symbol": "BTCUSD"}, func(raw json.RawMessage) {
+		btc <- raw
+	}, func() {})
+	s.subscribe("subscribeTicker", "ETHUSD", map[string]interface{}{"symbol": "ETHUSD"}, func(raw json.RawMessage) {
+		eth <- raw
+	}, func() {})
+
+	s.dispatch([]byte(`{"method":"ticker","params":{"symbol":"ETHUSD"}}`))
+
+	select {
+	case <-btc:
+		t.Fatal("ETHUSD notification was delivered to the BTCUSD subscriber")
+	case raw := <-eth:
+		var params struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := json.Unmarshal(raw, &params); err != nil {
+			t.Fatalf("unmarshal delivered params: %v", err)
+		}
+		if params.Symbol != "ETHUSD" {
+			t.Fatalf("got symbol %q, want ETHUSD", params.Symbol)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ticker notification was never delivered to the ETHUSD subscriber")
+	}
+}
+
+func TestStreamCloseClosesDeliveryChannels(t *testing.T) {
+	s := NewStream("", "")
+
+	ch := make(chan int)
+	s.mu.Lock()
+	s.closers = append(s.closers, func() { close(ch) })
+	s.mu.Unlock()
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned %v, want nil", err)
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel to be closed, got a value instead")
+		}
+	default:
+		t.Fatal("expected channel to be closed, but receive would have blocked")
+	}
+}
+
+func TestNotificationMethodsCoverAllSubscriptions(t *testing.T) {
+	for _, channel := range []string{
+		"subscribeTicker",
+		"subscribeOrderbook",
+		"subscribeTrades",
+		"subscribeCandles",
+		"subscribeReports",
+	} {
+		if len(notificationMethods[channel]) == 0 {
+			t.Errorf("no notification methods registered for %s", channel)
+		}
+	}
+}
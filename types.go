@@ -0,0 +1,14 @@
+package hitbtc
+
+// Symbol describes a single trading market and the metadata needed to
+// validate orders against it before they're submitted.
+type Symbol struct {
+	ID                   string  `json:"id"`
+	BaseCurrency         string  `json:"baseCurrency"`
+	QuoteCurrency        string  `json:"quoteCurrency"`
+	QuantityIncrement    Decimal `json:"quantityIncrement"`
+	TickSize             Decimal `json:"tickSize"`
+	TakeLiquidityRate    Decimal `json:"takeLiquidityRate"`
+	ProvideLiquidityRate Decimal `json:"provideLiquidityRate"`
+	FeeCurrency          string  `json:"feeCurrency"`
+}